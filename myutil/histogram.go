@@ -0,0 +1,270 @@
+package myutil
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cipepser/plot/plotter"
+	"gonum.org/v1/plot"
+	gonumplotter "gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Summary holds descriptive statistics computed over a distribution of
+// raw measurements.
+type Summary struct {
+	Min, Max, Mean, StdDev float64
+	P50, P90, P99, P999    float64
+}
+
+// summarize computes a Summary over x. x must be non-empty.
+func summarize(x []float64) Summary {
+	sorted := append([]float64(nil), x...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	var sqDiff float64
+	for _, v := range sorted {
+		d := v - mean
+		sqDiff += d * d
+	}
+	stddev := 0.0
+	if len(sorted) > 1 {
+		stddev = math.Sqrt(sqDiff / float64(len(sorted)-1))
+	}
+
+	return Summary{
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   mean,
+		StdDev: stddev,
+		P50:    percentile(sorted, 50),
+		P90:    percentile(sorted, 90),
+		P99:    percentile(sorted, 99),
+		P999:   percentile(sorted, 99.9),
+	}
+}
+
+// percentile returns the p-th percentile (0-100) of sorted, which must
+// already be sorted ascending, using linear interpolation between the
+// two nearest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	rank := (p / 100) * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return sorted[lo]
+	}
+
+	frac := rank - float64(lo)
+	return sorted[lo] + frac*(sorted[hi]-sorted[lo])
+}
+
+// HistogramOptions configures MyHistogramWithOptions.
+type HistogramOptions struct {
+	// Bins is the number of histogram buckets.
+	Bins int
+
+	// HighDynamicRange, when true, uses log-spaced bucket edges instead
+	// of evenly spaced ones, suitable for latency data spanning many
+	// orders of magnitude.
+	HighDynamicRange bool
+
+	Options
+}
+
+// MyHistogram renders x as a bar-chart histogram with bins buckets and
+// returns its Summary.
+func MyHistogram(x []float64, bins int) Summary {
+	s, err := MyHistogramWithOptions(x, HistogramOptions{Bins: bins})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// MyHistogramWithOptions is the configurable counterpart of MyHistogram,
+// additionally supporting log-spaced (HighDynamicRange) buckets and
+// Options-driven rendering.
+func MyHistogramWithOptions(x []float64, opts HistogramOptions) (Summary, error) {
+	if len(x) == 0 {
+		return Summary{}, fmt.Errorf("myutil: x must be non-empty")
+	}
+	if opts.Bins <= 0 {
+		return Summary{}, fmt.Errorf("myutil: bins must be positive, got %d", opts.Bins)
+	}
+
+	summary := summarize(x)
+
+	edges := linearEdges(summary.Min, summary.Max, opts.Bins)
+	if opts.HighDynamicRange {
+		edges = logEdges(summary.Min, summary.Max, opts.Bins)
+	}
+	counts := bucket(x, edges)
+
+	vals := make(gonumplotter.Values, len(counts))
+	for i, n := range counts {
+		vals[i] = float64(n)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	bc, err := gonumplotter.NewBarChart(vals, vg.Points(1))
+	if err != nil {
+		return Summary{}, err
+	}
+	p.Add(bc)
+
+	for _, pm := range []struct {
+		label string
+		value float64
+	}{
+		{"p50", summary.P50},
+		{"p90", summary.P90},
+		{"p99", summary.P99},
+		{"p99.9", summary.P999},
+	} {
+		p.Add(plotter.NewVRule(bucketIndex(edges, pm.value), pm.label))
+	}
+
+	p.Title.Text = "Histogram"
+	p.X.Label.Text = "Value"
+	p.Y.Label.Text = "Count"
+
+	if err := writePlot(p, opts.Options); err != nil {
+		return Summary{}, err
+	}
+
+	return summary, nil
+}
+
+// MyPercentiles renders a bar-chart-style percentile plot of x (X = the
+// percentile, Y = the observed value) for each percentile in pcts, and
+// returns its Summary.
+func MyPercentiles(x []float64, pcts []float64) Summary {
+	s, err := MyPercentilesWithOptions(x, pcts, Options{Open: true})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// MyPercentilesWithOptions is the Options-driven counterpart of
+// MyPercentiles, additionally rendering to opts.Writer or opts.Filename
+// in opts.Format instead of always shelling out to `open img.png`.
+func MyPercentilesWithOptions(x []float64, pcts []float64, opts Options) (Summary, error) {
+	if len(x) == 0 {
+		return Summary{}, fmt.Errorf("myutil: x must be non-empty")
+	}
+	for _, pc := range pcts {
+		if pc < 0 || pc > 100 {
+			return Summary{}, fmt.Errorf("myutil: percentile %g out of range [0, 100]", pc)
+		}
+	}
+
+	sorted := append([]float64(nil), x...)
+	sort.Float64s(sorted)
+	summary := summarize(x)
+
+	vals := make(gonumplotter.Values, len(pcts))
+	for i, p := range pcts {
+		vals[i] = percentile(sorted, p)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	bc, err := gonumplotter.NewBarChart(vals, vg.Points(20))
+	if err != nil {
+		return Summary{}, err
+	}
+	p.Add(bc)
+
+	labels := make([]string, len(pcts))
+	for i, pc := range pcts {
+		labels[i] = fmt.Sprintf("p%g", pc)
+	}
+	p.NominalX(labels...)
+
+	p.Title.Text = "Percentiles"
+	p.X.Label.Text = "Percentile"
+	p.Y.Label.Text = "Value"
+
+	if err := writePlot(p, opts); err != nil {
+		return Summary{}, err
+	}
+
+	return summary, nil
+}
+
+// linearEdges returns bins+1 evenly spaced bucket edges spanning [min, max].
+func linearEdges(min, max float64, bins int) []float64 {
+	edges := make([]float64, bins+1)
+	width := (max - min) / float64(bins)
+	for i := range edges {
+		edges[i] = min + width*float64(i)
+	}
+	return edges
+}
+
+// logEdges returns bins+1 log-spaced bucket edges spanning [min, max],
+// suitable for data spanning many orders of magnitude.
+func logEdges(min, max float64, bins int) []float64 {
+	if min <= 0 {
+		min = 1e-9
+	}
+
+	logMin, logMax := math.Log10(min), math.Log10(max)
+	step := (logMax - logMin) / float64(bins)
+
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = math.Pow(10, logMin+step*float64(i))
+	}
+	return edges
+}
+
+// bucketIndex maps a raw value to its fractional position along the bar
+// chart's bucket-index X axis, for overlaying a VRule at that value. The
+// bar for bucket i is centred on i, so edges[i] maps to i-0.5.
+func bucketIndex(edges []float64, value float64) float64 {
+	for i := 0; i < len(edges)-1; i++ {
+		if value >= edges[i] && value <= edges[i+1] {
+			return float64(i) - 0.5 + (value-edges[i])/(edges[i+1]-edges[i])
+		}
+	}
+	if value < edges[0] {
+		return -0.5
+	}
+	return float64(len(edges)-1) - 0.5
+}
+
+// bucket counts how many values of x fall in each [edges[i], edges[i+1])
+// bucket, with the final bucket's upper bound inclusive.
+func bucket(x []float64, edges []float64) []int {
+	counts := make([]int, len(edges)-1)
+	for _, v := range x {
+		for i := 0; i < len(edges)-1; i++ {
+			if v >= edges[i] && (v < edges[i+1] || i == len(edges)-2) {
+				counts[i]++
+				break
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,146 @@
+package myutil
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	gonumplotter "gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// SeriesStyle selects how a Series is rendered on a MyMultiPlot figure.
+type SeriesStyle int
+
+const (
+	Line SeriesStyle = iota
+	Scatter
+	LineScatter
+	Bar
+)
+
+// Series is one named line/scatter/bar to draw on a MyMultiPlot figure.
+type Series struct {
+	Name  string
+	X, Y  []float64
+	Style SeriesStyle
+
+	// Color, when nil, is auto-assigned from the default palette.
+	Color  color.Color
+	Dashes []vg.Length
+	Radius vg.Length
+}
+
+// defaultPalette is a Category10-like list of 10 distinguishable colors,
+// used to auto-assign Series.Color when it is left nil.
+var defaultPalette = []color.Color{
+	color.RGBA{R: 31, G: 119, B: 180, A: 255},
+	color.RGBA{R: 255, G: 127, B: 14, A: 255},
+	color.RGBA{R: 44, G: 160, B: 44, A: 255},
+	color.RGBA{R: 214, G: 39, B: 40, A: 255},
+	color.RGBA{R: 148, G: 103, B: 189, A: 255},
+	color.RGBA{R: 140, G: 86, B: 75, A: 255},
+	color.RGBA{R: 227, G: 119, B: 194, A: 255},
+	color.RGBA{R: 127, G: 127, B: 127, A: 255},
+	color.RGBA{R: 188, G: 189, B: 34, A: 255},
+	color.RGBA{R: 23, G: 190, B: 207, A: 255},
+}
+
+// SetDefaultPalette replaces the palette MyMultiPlot draws from when a
+// Series leaves Color nil, e.g. to switch to a colorblind-safe palette.
+func SetDefaultPalette(palette []color.Color) {
+	defaultPalette = palette
+}
+
+// MyMultiPlot adds every Series to one plot, auto-assigning colors from
+// the default palette where Color is nil, and labelling each in the
+// legend by its Name.
+func MyMultiPlot(series []Series, opts Options) error {
+	p, err := plot.New()
+	if err != nil {
+		return err
+	}
+
+	for i, s := range series {
+		if len(s.X) != len(s.Y) {
+			return fmt.Errorf("myutil: series %q: length of X and Y have to same.", s.Name)
+		}
+
+		col := s.Color
+		if col == nil {
+			col = defaultPalette[i%len(defaultPalette)]
+		}
+
+		data := make(gonumplotter.XYs, len(s.X))
+		for j := range s.X {
+			data[j].X = s.X[j]
+			data[j].Y = s.Y[j]
+		}
+
+		switch s.Style {
+		case Line:
+			l, err := gonumplotter.NewLine(data)
+			if err != nil {
+				return err
+			}
+			l.Color = col
+			l.Dashes = s.Dashes
+			p.Add(l)
+			p.Legend.Add(s.Name, l)
+
+		case Scatter:
+			sc, err := gonumplotter.NewScatter(data)
+			if err != nil {
+				return err
+			}
+			sc.Color = col
+			sc.Radius = radiusOrDefault(s.Radius)
+			p.Add(sc)
+			p.Legend.Add(s.Name, sc)
+
+		case LineScatter:
+			l, err := gonumplotter.NewLine(data)
+			if err != nil {
+				return err
+			}
+			l.Color = col
+			l.Dashes = s.Dashes
+
+			sc, err := gonumplotter.NewScatter(data)
+			if err != nil {
+				return err
+			}
+			sc.Color = col
+			sc.Radius = radiusOrDefault(s.Radius)
+
+			p.Add(l, sc)
+			p.Legend.Add(s.Name, l, sc)
+
+		case Bar:
+			vals := make(gonumplotter.Values, len(s.Y))
+			copy(vals, s.Y)
+
+			bc, err := gonumplotter.NewBarChart(vals, vg.Points(20))
+			if err != nil {
+				return err
+			}
+			bc.Color = col
+			p.Add(bc)
+			p.Legend.Add(s.Name, bc)
+
+		default:
+			return fmt.Errorf("myutil: series %q: unknown SeriesStyle %v", s.Name, s.Style)
+		}
+	}
+
+	return writePlot(p, opts)
+}
+
+// radiusOrDefault returns r, or a sensible default scatter glyph radius
+// when r is zero.
+func radiusOrDefault(r vg.Length) vg.Length {
+	if r == 0 {
+		return vg.Length(2)
+	}
+	return r
+}
@@ -0,0 +1,168 @@
+package myutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgpdf"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// Options controls how a plot produced by the myutil helpers is
+// rendered. The zero value renders a 10x6 inch PNG to "img.png" without
+// opening it; the legacy zero-arg My* helpers set Open to true
+// themselves to match their original behavior of always shelling out
+// to `open img.png`.
+type Options struct {
+	// Width and Height are the canvas size. They default to 10 and 6
+	// inches respectively when left zero.
+	Width, Height vg.Length
+
+	// Format selects the output encoding: "png", "jpg", "svg" or "pdf".
+	// It defaults to "png".
+	Format string
+
+	// Writer, when non-nil, receives the rendered plot instead of a
+	// file, and Open and Filename are ignored.
+	Writer io.Writer
+
+	// Filename is where the plot is written when Writer is nil. It
+	// defaults to "img.png".
+	Filename string
+
+	// Open shells out to `open` on Filename once it is written. It has
+	// no effect when Writer is set.
+	Open bool
+
+	// Title, XLabel and YLabel, when non-empty, override the plot's
+	// default labels.
+	Title, XLabel, YLabel string
+}
+
+// fill substitutes zero-valued fields of opts with their defaults.
+func (opts Options) fill() Options {
+	if opts.Width == 0 {
+		opts.Width = 10 * vg.Inch
+	}
+	if opts.Height == 0 {
+		opts.Height = 6 * vg.Inch
+	}
+	if opts.Format == "" {
+		opts.Format = "png"
+	}
+	if opts.Filename == "" {
+		opts.Filename = "img.png"
+	}
+	return opts
+}
+
+// writePlot applies opts' labels to p, renders it to opts.Writer or
+// opts.Filename according to opts.Format, and opens the result when
+// opts.Open is set.
+func writePlot(p *plot.Plot, opts Options) error {
+	opts = opts.fill()
+
+	if opts.Title != "" {
+		p.Title.Text = opts.Title
+	}
+	if opts.XLabel != "" {
+		p.X.Label.Text = opts.XLabel
+	}
+	if opts.YLabel != "" {
+		p.Y.Label.Text = opts.YLabel
+	}
+
+	w := opts.Writer
+	if w == nil {
+		f, err := os.Create(opts.Filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := render(p, opts.Width, opts.Height, opts.Format, w); err != nil {
+		return err
+	}
+
+	if opts.Open && opts.Writer == nil {
+		if err := exec.Command("open", opts.Filename).Run(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeCanvas is the writePlot counterpart for callers composing more
+// than one plot.Plot onto a shared canvas (e.g. stacked subplots):
+// drawFn receives the canvas to draw onto, and the result is rendered to
+// opts.Writer or opts.Filename according to opts.Format, then opened
+// when opts.Open is set.
+func writeCanvas(opts Options, drawFn func(draw.Canvas)) error {
+	opts = opts.fill()
+
+	w := opts.Writer
+	if w == nil {
+		f, err := os.Create(opts.Filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := renderCanvas(opts.Width, opts.Height, opts.Format, w, drawFn); err != nil {
+		return err
+	}
+
+	if opts.Open && opts.Writer == nil {
+		if err := exec.Command("open", opts.Filename).Run(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// render draws p onto a width by height canvas in the given format and
+// writes the encoded result to w.
+func render(p *plot.Plot, width, height vg.Length, format string, w io.Writer) error {
+	return renderCanvas(width, height, format, w, func(c draw.Canvas) { p.Draw(c) })
+}
+
+// renderCanvas builds a width by height canvas in the given format, runs
+// drawFn over it, and writes the encoded result to w.
+func renderCanvas(width, height vg.Length, format string, w io.Writer, drawFn func(draw.Canvas)) error {
+	switch format {
+	case "png":
+		c := vgimg.New(width, height)
+		drawFn(draw.New(c))
+		_, err := vgimg.PngCanvas{Canvas: c}.WriteTo(w)
+		return err
+	case "jpg", "jpeg":
+		c := vgimg.JpegCanvas{Canvas: vgimg.New(width, height)}
+		drawFn(draw.New(c))
+		_, err := c.WriteTo(w)
+		return err
+	case "svg":
+		c := vgsvg.New(width, height)
+		drawFn(draw.New(c))
+		_, err := c.WriteTo(w)
+		return err
+	case "pdf":
+		c := vgpdf.New(width, height)
+		drawFn(draw.New(c))
+		_, err := c.WriteTo(w)
+		return err
+	default:
+		return fmt.Errorf("myutil: unsupported format %q", format)
+	}
+}
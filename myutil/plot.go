@@ -1,52 +1,45 @@
 package myutil
 
 import (
-	"log"
-	"os/exec"
+	"errors"
+	"fmt"
+	"image/color"
 	"strconv"
+	"time"
 
 	"github.com/cipepser/plot/plotter"
+	"github.com/gonum/plot/vg/draw"
 	"gonum.org/v1/plot"
+	gonumplotter "gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
 // MySinglePlot is a wrapper of Line of package plotter with slice of float64 x.
 func MySinglePlot(x []float64) {
-	data := make(plotter.XYs, len(x))
-	for i := 0; i < len(x); i++ {
-		data[i].X = float64(i)
-		data[i].Y = x[i]
-	}
-
-	p, err := plot.New()
-	if err != nil {
-		panic(err)
+	idx := make([]float64, len(x))
+	for i := range idx {
+		idx[i] = float64(i)
 	}
 
-	l, err := plotter.NewLine(data)
-	if err != nil {
-		panic(err)
-	}
-
-	p.Add(l)
-
-	file := "img.png"
-	if err = p.Save(10*vg.Inch, 6*vg.Inch, file); err != nil {
-		panic(err)
-	}
+	MyPlot(idx, x)
+}
 
-	if err = exec.Command("open", file).Run(); err != nil {
+// MyPlot is a wrapper of Line of package plotter with slice of float64 x and y.
+func MyPlot(x, y []float64) {
+	if err := MyPlotTo(x, y, Options{Open: true}); err != nil {
 		panic(err)
 	}
 }
 
-// MyPlot is a wrapper of Line of package plotter with slice of float64 x and y.
-func MyPlot(x, y []float64) {
+// MyPlotTo is the Options-driven counterpart of MyPlot: it builds the
+// same single-series line plot, but leaves the output destination,
+// encoding and whether to open the result up to opts.
+func MyPlotTo(x, y []float64, opts Options) error {
 	if len(x) != len(y) {
-		log.Fatal("length of x and y have to same.")
+		return errors.New("length of x and y have to same.")
 	}
 
-	data := make(plotter.XYs, len(x))
+	data := make(gonumplotter.XYs, len(x))
 	for i := 0; i < len(x); i++ {
 		data[i].X = x[i]
 		data[i].Y = y[i]
@@ -54,66 +47,46 @@ func MyPlot(x, y []float64) {
 
 	p, err := plot.New()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	l, err := plotter.NewLine(data)
+	l, err := gonumplotter.NewLine(data)
 	if err != nil {
-		panic(err)
+		return err
 	}
-
 	p.Add(l)
 
-	file := "img.png"
-	if err = p.Save(10*vg.Inch, 6*vg.Inch, file); err != nil {
-		panic(err)
-	}
-
-	if err = exec.Command("open", file).Run(); err != nil {
-		panic(err)
-	}
+	return writePlot(p, opts)
 }
 
 // MySingleScatter is a wrapper of Scatter of package plotter with slice of float64 x.
 func MySingleScatter(x []float64) {
-	data := make(plotter.XYs, len(x))
-	for i := 0; i < len(x); i++ {
-		data[i].X = float64(i)
-		data[i].Y = x[i]
-	}
-
-	p, err := plot.New()
-	if err != nil {
-		panic(err)
+	idx := make([]float64, len(x))
+	for i := range idx {
+		idx[i] = float64(i)
 	}
 
-	s, err := plotter.NewScatter(data)
-	if err != nil {
-		panic(err)
-	}
-
-	s.Radius = vg.Length(1)
-
-	p.Add(s)
-
-	file := "img.png"
-	if err = p.Save(10*vg.Inch, 6*vg.Inch, file); err != nil {
+	if err := MyScatterTo(idx, x, vg.Length(1), Options{Open: true}); err != nil {
 		panic(err)
 	}
+}
 
-	if err = exec.Command("open", file).Run(); err != nil {
+// MyScatter is a wrapper of Scatter of package plotter with slice of float64 x and y.
+func MyScatter(x, y []float64) {
+	if err := MyScatterTo(x, y, vg.Length(2), Options{Open: true}); err != nil {
 		panic(err)
 	}
-
 }
 
-// MyScatter is a wrapper of Scatter of package plotter with slice of float64 x and y.
-func MyScatter(x, y []float64) {
+// MyScatterTo is the Options-driven counterpart of MyScatter: radius
+// sets the glyph radius, and opts controls where and how the plot is
+// rendered instead of always opening "img.png".
+func MyScatterTo(x, y []float64, radius vg.Length, opts Options) error {
 	if len(x) != len(y) {
-		log.Fatal("length of x and y have to same.")
+		return errors.New("length of x and y have to same.")
 	}
 
-	data := make(plotter.XYs, len(x))
+	data := make(gonumplotter.XYs, len(x))
 	for i := 0; i < len(x); i++ {
 		data[i].X = x[i]
 		data[i].Y = y[i]
@@ -121,35 +94,26 @@ func MyScatter(x, y []float64) {
 
 	p, err := plot.New()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	s, err := plotter.NewScatter(data)
+	s, err := gonumplotter.NewScatter(data)
 	if err != nil {
-		panic(err)
+		return err
 	}
-
-	s.Radius = vg.Length(2)
-
+	s.Radius = radius
 	p.Add(s)
 
-	file := "img.png"
-	if err = p.Save(10*vg.Inch, 6*vg.Inch, file); err != nil {
-		panic(err)
-	}
-
-	if err = exec.Command("open", file).Run(); err != nil {
-		panic(err)
-	}
+	return writePlot(p, opts)
 }
 
 // MyPlotWithScatter draw plot and scatter at once.
 func MyPlotWithScatter(x, y []float64) {
 	if len(x) != len(y) {
-		log.Fatal("length of x and y have to same.")
+		panic(errors.New("length of x and y have to same."))
 	}
 
-	data := make(plotter.XYs, len(x))
+	data := make(gonumplotter.XYs, len(x))
 	for i := 0; i < len(x); i++ {
 		data[i].X = x[i]
 		data[i].Y = y[i]
@@ -160,72 +124,166 @@ func MyPlotWithScatter(x, y []float64) {
 		panic(err)
 	}
 
-	s, err := plotter.NewScatter(data)
+	s, err := gonumplotter.NewScatter(data)
 	if err != nil {
 		panic(err)
 	}
-
 	s.Radius = vg.Length(2)
 	p.Add(s)
 
-	l, err := plotter.NewLine(data)
+	l, err := gonumplotter.NewLine(data)
 	if err != nil {
 		panic(err)
 	}
-
 	p.Add(l)
 
-	file := "img.png"
-	if err = p.Save(10*vg.Inch, 6*vg.Inch, file); err != nil {
+	if err := writePlot(p, Options{Open: true}); err != nil {
 		panic(err)
 	}
+}
 
-	if err = exec.Command("open", file).Run(); err != nil {
+// MyCandleChart draws the candle chart with data. ts is either the
+// label for each candle ([]string, via plot.NominalX) or the real
+// timestamp of each candle ([]time.Time, via a plotter.TimeTicks axis),
+// letting gaps in the timestamps (weekends, holidays) show up as blank
+// space instead of being collapsed.
+func MyCandleChart(ts interface{}, data [][]float64, bu plotter.BarUnit) {
+	if err := MyCandleChartTo(ts, data, bu, Options{Open: true}); err != nil {
 		panic(err)
 	}
 }
 
-// MyCandleChart draw the candle chart with data.
-// ts represents the time which used as label.
-func MyCandleChart(ts []string, data [][]float64, bu plotter.BarUnit) {
+// MyCandleChartTo is the Options-driven counterpart of MyCandleChart: it
+// dispatches to the nominal or time-axis variant based on the type of
+// ts, and opts controls where and how the result is rendered.
+func MyCandleChartTo(ts interface{}, data [][]float64, bu plotter.BarUnit, opts Options) error {
+	switch t := ts.(type) {
+	case []string:
+		return myCandleChartNominalTo(t, data, bu, opts)
+	case []time.Time:
+		return myCandleChartTimeTo(t, data, bu, opts)
+	default:
+		return fmt.Errorf("myutil: ts must be []string or []time.Time, got %T", ts)
+	}
+}
+
+// myCandleChartNominalTo renders a candle chart labelled by the given
+// per-candle strings.
+func myCandleChartNominalTo(ts []string, data [][]float64, bu plotter.BarUnit, opts Options) error {
 	p, err := plot.New()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	cc, err := plotter.NewCandleChart(data)
 	if err != nil {
-		panic(err)
+		return err
 	}
-
 	p.Add(cc)
 
-	// tunit := "min"
 	cunit := "yen"
 	p.Title.Text = "Candle Chart"
-	p.X.Label.Text = "Time"
 	p.X.Label.Text = "Time [" + strconv.Itoa(bu.T) + " " + plotter.TransFormat2Unit(bu.Unit) + "]"
 	p.Y.Label.Text = "Price [" + cunit + "]"
 
-	// fmt.Println("")
-	// p.X.Tick.Marker.Ticks(0, 0)
-	// fmt.Println(p.X.Tick.Marker.Ticks(0, 0)[0])
-	// fmt.Println(p.Y.Tick.Marker.Ticks(0, 0)[0])
 	p.Y.Tick.Marker = plotter.RawTicks{}
-	// p.Y.Tick.Marker = commaTicks{}
 
 	p.NominalX(ts...)
 
 	p.X.Min = -0.5
 	p.X.Max = float64(len(data)) * 1.1
 
-	file := "img.png"
-	if err = p.Save(10*vg.Inch, 6*vg.Inch, file); err != nil {
-		panic(err)
+	return writePlot(p, opts)
+}
+
+// myCandleChartTimeTo renders a candle chart whose candles are
+// positioned by their real timestamp, with a plotter.TimeTicks X axis.
+func myCandleChartTimeTo(ts []time.Time, data [][]float64, bu plotter.BarUnit, opts Options) error {
+	p, err := plot.New()
+	if err != nil {
+		return err
 	}
 
-	if err = exec.Command("open", file).Run(); err != nil {
+	cc, err := plotter.NewCandleChartTime(ts, data, bu)
+	if err != nil {
+		return err
+	}
+	p.Add(cc)
+
+	cunit := "yen"
+	p.Title.Text = "Candle Chart"
+	p.X.Label.Text = "Time [" + strconv.Itoa(bu.T) + " " + plotter.TransFormat2Unit(bu.Unit) + "]"
+	p.Y.Label.Text = "Price [" + cunit + "]"
+
+	p.Y.Tick.Marker = plotter.RawTicks{}
+	p.X.Tick.Marker = plotter.NewTimeTicks(ts, bu)
+
+	xmin, xmax, _, _ := cc.DataRange()
+	p.X.Min = xmin - 0.5
+	p.X.Max = xmax
+
+	return writePlot(p, opts)
+}
+
+// MyCandleChartWithIndicators draws the candle chart with data, overlaid
+// with a Simple Moving Average, Exponential Moving Average and Bollinger
+// Bands, each computed with period n (typically 20) over the close
+// price of every candle. m is the Bollinger Band width in standard
+// deviations (typically 2).
+func MyCandleChartWithIndicators(ts []string, data [][]float64, bu plotter.BarUnit, n int, m float64) {
+	if err := MyCandleChartWithIndicatorsTo(ts, data, bu, n, m, Options{Open: true}); err != nil {
 		panic(err)
 	}
+}
+
+// MyCandleChartWithIndicatorsTo is the Options-driven counterpart of
+// MyCandleChartWithIndicators: opts controls where and how the result
+// is rendered instead of always opening "img.png".
+func MyCandleChartWithIndicatorsTo(ts []string, data [][]float64, bu plotter.BarUnit, n int, m float64, opts Options) error {
+	p, err := plot.New()
+	if err != nil {
+		return err
+	}
+
+	cc, err := plotter.NewCandleChart(data)
+	if err != nil {
+		return err
+	}
+	p.Add(cc)
+
+	smaStyle := draw.LineStyle{Color: color.RGBA{B: 200, A: 255}, Width: vg.Points(1)}
+	sma, err := plotter.NewSMA(data, n, smaStyle)
+	if err != nil {
+		return err
+	}
+	p.Add(sma)
+
+	emaStyle := draw.LineStyle{Color: color.RGBA{R: 200, A: 255}, Width: vg.Points(1)}
+	ema, err := plotter.NewEMA(data, n, emaStyle)
+	if err != nil {
+		return err
+	}
+	p.Add(ema)
+
+	bandStyle := draw.LineStyle{Color: color.Gray{Y: 128}, Width: vg.Points(1)}
+	bandFill := color.RGBA{R: 128, G: 128, B: 128, A: 40}
+	bb, err := plotter.NewBollingerBands(data, n, m, bandStyle, bandFill)
+	if err != nil {
+		return err
+	}
+	p.Add(bb)
+
+	cunit := "yen"
+	p.Title.Text = "Candle Chart"
+	p.X.Label.Text = "Time [" + strconv.Itoa(bu.T) + " " + plotter.TransFormat2Unit(bu.Unit) + "]"
+	p.Y.Label.Text = "Price [" + cunit + "]"
+
+	p.Y.Tick.Marker = plotter.RawTicks{}
+
+	p.NominalX(ts...)
+
+	p.X.Min = -0.5
+	p.X.Max = float64(len(data)) * 1.1
 
+	return writePlot(p, opts)
 }
@@ -0,0 +1,86 @@
+package myutil
+
+import (
+	"strconv"
+
+	"github.com/cipepser/plot/plotter"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// MyCandleChartWithVolume draws the candle chart with data above a
+// volume bars subplot fed by volumes, sharing one X axis split roughly
+// 75/25 between the two panes so bars line up under their candles.
+func MyCandleChartWithVolume(ts []string, data [][]float64, volumes []float64, bu plotter.BarUnit) {
+	if err := MyCandleChartWithVolumeTo(ts, data, volumes, bu, Options{}); err != nil {
+		panic(err)
+	}
+}
+
+// MyCandleChartWithVolumeTo is the Options-driven counterpart of
+// MyCandleChartWithVolume: it composes the candle and volume panes onto
+// one canvas via writeCanvas, so opts controls the shared output
+// instead of each pane opening its own "img.png".
+func MyCandleChartWithVolumeTo(ts []string, data [][]float64, volumes []float64, bu plotter.BarUnit, opts Options) error {
+	candles, err := plotter.NewCandles(data)
+	if err != nil {
+		return err
+	}
+
+	candleP, err := plot.New()
+	if err != nil {
+		return err
+	}
+
+	cc, err := plotter.NewCandleChart(data)
+	if err != nil {
+		return err
+	}
+	candleP.Add(cc)
+
+	cunit := "yen"
+	candleP.Title.Text = "Candle Chart"
+	candleP.X.Label.Text = "Time [" + strconv.Itoa(bu.T) + " " + plotter.TransFormat2Unit(bu.Unit) + "]"
+	candleP.Y.Label.Text = "Price [" + cunit + "]"
+	candleP.Y.Tick.Marker = plotter.RawTicks{}
+	candleP.NominalX(ts...)
+	candleP.X.Min = -0.5
+	candleP.X.Max = float64(len(data)) * 1.1
+
+	vb, err := plotter.NewVolumeBars(candles, volumes)
+	if err != nil {
+		return err
+	}
+
+	volumeP, err := plot.New()
+	if err != nil {
+		return err
+	}
+	volumeP.Add(vb)
+
+	volumeP.Y.Label.Text = "Volume"
+	volumeP.Y.Tick.Marker = plotter.RawTicks{}
+	volumeP.NominalX(ts...)
+	volumeP.X.Min = candleP.X.Min
+	volumeP.X.Max = candleP.X.Max
+
+	return writeCanvas(opts, func(dc draw.Canvas) {
+		// Tiles divides the canvas into Rows equal-height bands; the
+		// candle pane spans the top 3 bands (75%) and the volume pane
+		// the bottom band (25%), both spanning the full width so their
+		// shared X axis lines up exactly.
+		tiles := draw.Tiles{Rows: 4, Cols: 1}
+
+		top := tiles.At(dc, 0, 0)
+		candleBottom := tiles.At(dc, 0, 2)
+		candlePane := draw.Canvas{
+			Canvas:    dc,
+			Rectangle: vg.Rectangle{Min: candleBottom.Min, Max: top.Max},
+		}
+		volumePane := tiles.At(dc, 0, 3)
+
+		candleP.Draw(candlePane)
+		volumeP.Draw(volumePane)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"image/color"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/vg"
@@ -21,7 +22,9 @@ const (
 	FormatYear   string = "2006"
 )
 
-func transFormat2Unit(f string) string {
+// TransFormat2Unit maps one of the Format* constants to the short unit
+// abbreviation myutil uses to label a candle chart's X axis.
+func TransFormat2Unit(f string) string {
 	switch f {
 	case FormatSecond:
 		return "sec"
@@ -180,6 +183,48 @@ func NewCandleChart(data [][]float64) (*CandleChart, error) {
 	return cc, nil
 }
 
+// NewCandleChartTime creates a new candle chart plotter like
+// NewCandleChart, but positions each candle's X coordinate using its
+// real timestamp rather than its sequential index, so that gaps in
+// times (e.g. weekends or holidays) show up as blank space instead of
+// being collapsed.
+func NewCandleChartTime(times []time.Time, data [][]float64, bu BarUnit) (*CandleChart, error) {
+	if len(times) != len(data) {
+		return nil, errors.New("length of times and data have to be same.")
+	}
+
+	cc := new(CandleChart)
+
+	step := barDuration(bu.Unit)
+	t0 := times[0]
+
+	candles := make([]Candle, len(data))
+	for i, d := range data {
+		x := times[i].Sub(t0).Seconds() / step.Seconds()
+
+		c, err := NewCandle(x, d)
+		if err != nil {
+			return nil, err
+		}
+		candles[i] = *c
+	}
+	cc.candles = candles
+
+	cc.Min = getMin(cc.candles)
+	cc.Max = getMax(cc.candles)
+
+	cc.GlyphStyle = DefaultGlyphStyle
+	cc.CandleStyle = draw.LineStyle{
+		Color: color.Black,
+		Width: vg.Points(1),
+	}
+	cc.WhiskerStyle = draw.LineStyle{
+		Width: vg.Points(1),
+	}
+
+	return cc, nil
+}
+
 // Plot implements the Plot method of the plot.Plotter interface.
 func (cc *CandleChart) Plot(c draw.Canvas, plt *plot.Plot) {
 	if len(cc.candles) < 2 {
@@ -188,8 +233,12 @@ func (cc *CandleChart) Plot(c draw.Canvas, plt *plot.Plot) {
 
 	trX, trY := plt.Transforms(&c)
 
+	// Derive the candle width from a single bar-unit step rather than the
+	// gap between the first two candles: NewCandleChartTime can place
+	// candles at non-uniform X positions (weekends/holidays), so the
+	// first gap is not representative of the rest of the series.
 	var w vg.Length
-	w = trX(cc.candles[1].X) - trX(cc.candles[0].X)
+	w = trX(cc.candles[0].X+1) - trX(cc.candles[0].X)
 
 	for _, candle := range cc.candles {
 		x := trX(candle.X)
@@ -232,14 +281,21 @@ func (cc *CandleChart) Plot(c draw.Canvas, plt *plot.Plot) {
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (cc *CandleChart) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return 0, float64(len(cc.candles)) * 1.3, cc.Min, cc.Max
+	xmax = float64(len(cc.candles))
+	if n := len(cc.candles); n > 0 {
+		if last := cc.candles[n-1].X + 1; last > xmax {
+			xmax = last
+		}
+	}
+	return 0, xmax * 1.3, cc.Min, cc.Max
 }
 
-type rawTicks struct{}
+// RawTicks implements the plot.Ticker interface.
+type RawTicks struct{}
 
 // Ticks computes the default tick marks, but the labels
 // are printed as raw number not float fromat.
-func (rawTicks) Ticks(min, max float64) []plot.Tick {
+func (RawTicks) Ticks(min, max float64) []plot.Tick {
 	tks := plot.DefaultTicks{}.Ticks(min, max)
 	for i, t := range tks {
 		if t.Label == "" { // Skip minor ticks, they are fine.
@@ -0,0 +1,259 @@
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// closes extracts the close price (the last value of each row) from OHLC
+// data, which is what technical indicators are computed from. It returns
+// an error if any row is empty.
+func closes(data [][]float64) ([]float64, error) {
+	cs := make([]float64, len(data))
+	for i, d := range data {
+		if len(d) == 0 {
+			return nil, errors.New("length of data is 0, must have positive length.")
+		}
+		cs[i] = d[len(d)-1]
+	}
+	return cs, nil
+}
+
+// sma computes a Simple Moving Average of period n over closes, with
+// math.NaN() filling the warm-up window where fewer than n points are
+// available.
+func sma(closes []float64, n int) []float64 {
+	ys := make([]float64, len(closes))
+	var sum float64
+	for i, c := range closes {
+		sum += c
+		if i >= n {
+			sum -= closes[i-n]
+		}
+		if i < n-1 {
+			ys[i] = math.NaN()
+			continue
+		}
+		ys[i] = sum / float64(n)
+	}
+	return ys
+}
+
+// ema computes an Exponential Moving Average of period n over closes,
+// seeded from the SMA of the first n points, with math.NaN() filling the
+// warm-up window.
+func ema(closes []float64, n int) []float64 {
+	ys := make([]float64, len(closes))
+	k := 2 / (float64(n) + 1)
+
+	smas := sma(closes, n)
+	for i := range closes {
+		switch {
+		case i < n-1:
+			ys[i] = math.NaN()
+		case i == n-1:
+			ys[i] = smas[i]
+		default:
+			ys[i] = closes[i]*k + ys[i-1]*(1-k)
+		}
+	}
+	return ys
+}
+
+// stddev computes the sample standard deviation of closes[i-n+1 : i+1]
+// about means[i] for each i, with math.NaN() filling the warm-up window.
+func stddev(closes []float64, n int, means []float64) []float64 {
+	ys := make([]float64, len(closes))
+	for i := range closes {
+		if i < n-1 {
+			ys[i] = math.NaN()
+			continue
+		}
+
+		var sum float64
+		for _, c := range closes[i-n+1 : i+1] {
+			d := c - means[i]
+			sum += d * d
+		}
+		ys[i] = math.Sqrt(sum / float64(n-1))
+	}
+	return ys
+}
+
+// xRange returns the candle X coordinates 0..n-1, matching the positions
+// CandleChart lays its candles out on.
+func xRange(n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	return xs
+}
+
+// Indicator implements the plot.Plotter interface, drawing a single line
+// technical overlay (e.g. SMA or EMA) aligned to a CandleChart's X
+// coordinates. Points in the warm-up window are math.NaN() and are
+// skipped rather than drawn.
+type Indicator struct {
+	Xs, Ys []float64
+
+	// LineStyle is the style of the indicator line.
+	LineStyle draw.LineStyle
+}
+
+// NewSMA computes a Simple Moving Average indicator of period n over the
+// close price of each row in data.
+func NewSMA(data [][]float64, n int, style draw.LineStyle) (*Indicator, error) {
+	if n <= 0 || n > len(data) {
+		return nil, errors.New("n must be positive and no greater than the number of candles.")
+	}
+
+	cs, err := closes(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Indicator{Xs: xRange(len(cs)), Ys: sma(cs, n), LineStyle: style}, nil
+}
+
+// NewEMA computes an Exponential Moving Average indicator of period n
+// over the close price of each row in data, seeded from the SMA of the
+// first n points.
+func NewEMA(data [][]float64, n int, style draw.LineStyle) (*Indicator, error) {
+	if n <= 0 || n > len(data) {
+		return nil, errors.New("n must be positive and no greater than the number of candles.")
+	}
+
+	cs, err := closes(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Indicator{Xs: xRange(len(cs)), Ys: ema(cs, n), LineStyle: style}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface, drawing
+// a line through consecutive non-NaN points and leaving a gap across any
+// NaN warm-up window.
+func (ind *Indicator) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	var line []vg.Point
+	flush := func() {
+		if len(line) > 1 {
+			c.StrokeLines(ind.LineStyle, c.ClipLinesY(line)...)
+		}
+		line = line[:0]
+	}
+
+	for i, y := range ind.Ys {
+		if math.IsNaN(y) {
+			flush()
+			continue
+		}
+		line = append(line, vg.Point{X: trX(ind.Xs[i]), Y: trY(y)})
+	}
+	flush()
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface, ignoring the NaN warm-up window.
+func (ind *Indicator) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for i, y := range ind.Ys {
+		if math.IsNaN(y) {
+			continue
+		}
+		ymin = math.Min(ymin, y)
+		ymax = math.Max(ymax, y)
+		xmin = math.Min(xmin, ind.Xs[i])
+		xmax = math.Max(xmax, ind.Xs[i])
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// BollingerBands implements the plot.Plotter interface, drawing an SMA
+// midline flanked by an upper and lower band at SMA(n) ± m*σ, with the
+// region between the bands shaded with FillColor.
+type BollingerBands struct {
+	Middle, Upper, Lower *Indicator
+
+	// FillColor shades the region between Upper and Lower.
+	FillColor color.Color
+}
+
+// NewBollingerBands computes Bollinger Bands of period n and width m
+// standard deviations over the close price of each row in data. style is
+// used for all three lines and fillColor shades the region between them.
+func NewBollingerBands(data [][]float64, n int, m float64, style draw.LineStyle, fillColor color.Color) (*BollingerBands, error) {
+	if n <= 0 || n > len(data) {
+		return nil, errors.New("n must be positive and no greater than the number of candles.")
+	}
+
+	cs, err := closes(data)
+	if err != nil {
+		return nil, err
+	}
+	xs := xRange(len(cs))
+	means := sma(cs, n)
+	sigmas := stddev(cs, n, means)
+
+	upper := make([]float64, len(cs))
+	lower := make([]float64, len(cs))
+	for i := range cs {
+		upper[i] = means[i] + m*sigmas[i]
+		lower[i] = means[i] - m*sigmas[i]
+	}
+
+	return &BollingerBands{
+		Middle:    &Indicator{Xs: xs, Ys: means, LineStyle: style},
+		Upper:     &Indicator{Xs: xs, Ys: upper, LineStyle: style},
+		Lower:     &Indicator{Xs: xs, Ys: lower, LineStyle: style},
+		FillColor: fillColor,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (bb *BollingerBands) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	var band []vg.Point
+	flush := func() {
+		if len(band) > 2 {
+			c.FillPolygon(bb.FillColor, c.ClipPolygonY(band))
+		}
+		band = band[:0]
+	}
+
+	for i := range bb.Upper.Ys {
+		if math.IsNaN(bb.Upper.Ys[i]) || math.IsNaN(bb.Lower.Ys[i]) {
+			flush()
+			continue
+		}
+		band = append(band, vg.Point{X: trX(bb.Upper.Xs[i]), Y: trY(bb.Upper.Ys[i])})
+	}
+	// Close the shaded polygon by walking back along the lower band.
+	for i := len(bb.Lower.Ys) - 1; i >= 0; i-- {
+		if math.IsNaN(bb.Lower.Ys[i]) {
+			continue
+		}
+		band = append(band, vg.Point{X: trX(bb.Lower.Xs[i]), Y: trY(bb.Lower.Ys[i])})
+	}
+	flush()
+
+	bb.Middle.Plot(c, plt)
+	bb.Upper.Plot(c, plt)
+	bb.Lower.Plot(c, plt)
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface.
+func (bb *BollingerBands) DataRange() (xmin, xmax, ymin, ymax float64) {
+	_, _, uymin, uymax := bb.Upper.DataRange()
+	lxmin, lxmax, lymin, lymax := bb.Lower.DataRange()
+	return lxmin, lxmax, math.Min(uymin, lymin), math.Max(uymax, lymax)
+}
@@ -0,0 +1,120 @@
+package plotter
+
+import (
+	"time"
+
+	"github.com/gonum/plot"
+)
+
+// maxTimeTicks caps the number of major ticks TimeTicks produces, so
+// long series stay readable.
+const maxTimeTicks = 10
+
+// TimeTicks implements the plot.Ticker interface, producing a bounded
+// number of ticks for a candle chart whose X axis carries real
+// timestamps, snapped to whichever boundary (minute/hour/day/month/
+// year) suits BarUnit and labelled with the format matching BarUnit.
+type TimeTicks struct {
+	Times   []time.Time
+	BarUnit BarUnit
+}
+
+// NewTimeTicks constructs a TimeTicks for times, labelled according to
+// bu.
+func NewTimeTicks(times []time.Time, bu BarUnit) TimeTicks {
+	return TimeTicks{Times: times, BarUnit: bu}
+}
+
+// Ticks implements the Ticks method of the plot.Ticker interface.
+func (t TimeTicks) Ticks(min, max float64) []plot.Tick {
+	if len(t.Times) == 0 {
+		return nil
+	}
+
+	candidates := boundaryIndices(t.Times, t.BarUnit.Unit)
+	if len(candidates) == 0 {
+		candidates = allIndices(len(t.Times))
+	}
+
+	step := (len(candidates) + maxTimeTicks - 1) / maxTimeTicks
+	if step < 1 {
+		step = 1
+	}
+
+	// Place each tick at the same elapsed-time X coordinate that
+	// NewCandleChartTime gives the candle at that index.
+	unit := barDuration(t.BarUnit.Unit).Seconds()
+
+	var tks []plot.Tick
+	for i := 0; i < len(candidates); i += step {
+		idx := candidates[i]
+		x := t.Times[idx].Sub(t.Times[0]).Seconds() / unit
+		if x < min || x > max {
+			continue
+		}
+		tks = append(tks, plot.Tick{Value: x, Label: t.Times[idx].Format(t.BarUnit.Unit)})
+	}
+	return tks
+}
+
+// boundaryIndices returns the indices of times that fall on a "round"
+// boundary for unit (e.g. midnight for a daily bar), which is where
+// ticks read most naturally.
+func boundaryIndices(times []time.Time, unit string) []int {
+	var idxs []int
+	for i, ts := range times {
+		if isBoundary(ts, unit) {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs
+}
+
+func isBoundary(ts time.Time, unit string) bool {
+	switch unit {
+	case FormatSecond:
+		return ts.Nanosecond() == 0
+	case FormatMinute:
+		return ts.Second() == 0
+	case FormatHour:
+		return ts.Minute() == 0
+	case FormatDay:
+		return ts.Hour() == 0
+	case FormatMonth:
+		return ts.Day() == 1
+	case FormatYear:
+		return ts.Month() == time.January && ts.Day() == 1
+	default:
+		return false
+	}
+}
+
+func allIndices(n int) []int {
+	idxs := make([]int, n)
+	for i := range idxs {
+		idxs[i] = i
+	}
+	return idxs
+}
+
+// barDuration returns the real-world duration spanned by a single bar of
+// the given unit, used to position candles by elapsed time rather than
+// sequential index.
+func barDuration(unit string) time.Duration {
+	switch unit {
+	case FormatSecond:
+		return time.Second
+	case FormatMinute:
+		return time.Minute
+	case FormatHour:
+		return time.Hour
+	case FormatDay:
+		return 24 * time.Hour
+	case FormatMonth:
+		return 30 * 24 * time.Hour
+	case FormatYear:
+		return 365 * 24 * time.Hour
+	default:
+		return time.Minute
+	}
+}
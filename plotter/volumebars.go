@@ -0,0 +1,102 @@
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// DefaultUpColor and DefaultDownColor are the fill colors VolumeBars
+// uses for bars whose parent candle closed up or down, respectively.
+var (
+	DefaultUpColor   = color.RGBA{G: 150, A: 255}
+	DefaultDownColor = color.RGBA{R: 200, A: 255}
+)
+
+// VolumeBars implements the plot.Plotter interface, drawing a volume
+// histogram using the same per-candle X coordinate and width computation
+// as CandleChart.Plot, colored by the parent candle's up/down direction.
+type VolumeBars struct {
+	Candles
+	Volumes []float64
+
+	// UpColor and DownColor fill bars whose parent candle closed up or
+	// down, respectively.
+	UpColor, DownColor color.Color
+
+	// Max is the largest volume, used for the Y axis.
+	Max float64
+}
+
+// NewVolumeBars constructs a VolumeBars for candles and their per-candle
+// volumes.
+func NewVolumeBars(candles []Candle, volumes []float64) (*VolumeBars, error) {
+	if len(candles) != len(volumes) {
+		return nil, errors.New("length of candles and volumes have to be same.")
+	}
+	if len(volumes) == 0 {
+		return nil, errors.New("length of volumes is 0, must have positive length.")
+	}
+
+	vb := &VolumeBars{
+		Candles:   Candles{candles: candles},
+		Volumes:   volumes,
+		UpColor:   DefaultUpColor,
+		DownColor: DefaultDownColor,
+	}
+
+	vb.Max = volumes[0]
+	for _, v := range volumes {
+		vb.Max = math.Max(vb.Max, v)
+	}
+
+	return vb, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (vb *VolumeBars) Plot(c draw.Canvas, plt *plot.Plot) {
+	if len(vb.candles) < 2 {
+		return
+	}
+
+	trX, trY := plt.Transforms(&c)
+
+	// Derive the bar width from a single bar-unit step rather than the
+	// gap between the first two candles: see CandleChart.Plot.
+	w := trX(vb.candles[0].X+1) - trX(vb.candles[0].X)
+	base := trY(0)
+
+	for i, candle := range vb.candles {
+		x := trX(candle.X)
+		top := trY(vb.Volumes[i])
+
+		col := vb.UpColor
+		if candle.start > candle.end {
+			col = vb.DownColor
+		}
+
+		pts := []vg.Point{
+			{X: x - w/2, Y: base},
+			{X: x - w/2, Y: top},
+			{X: x + w/2, Y: top},
+			{X: x + w/2, Y: base},
+		}
+		c.FillPolygon(col, c.ClipPolygonY(pts))
+	}
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface.
+func (vb *VolumeBars) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmax = float64(len(vb.candles))
+	if n := len(vb.candles); n > 0 {
+		if last := vb.candles[n-1].X + 1; last > xmax {
+			xmax = last
+		}
+	}
+	return 0, xmax * 1.3, 0, vb.Max * 1.1
+}
@@ -0,0 +1,51 @@
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// VRule implements the plot.Plotter interface, drawing a single labelled
+// vertical line at X, e.g. to mark a percentile on a histogram.
+type VRule struct {
+	X     float64
+	Label string
+
+	LineStyle draw.LineStyle
+	TextStyle draw.TextStyle
+}
+
+// NewVRule constructs a VRule at x labelled with label, using a dashed
+// line so it reads distinctly from histogram bars.
+func NewVRule(x float64, label string) *VRule {
+	return &VRule{
+		X:     x,
+		Label: label,
+		LineStyle: draw.LineStyle{
+			Color:  color.Black,
+			Width:  vg.Points(1),
+			Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
+		},
+		TextStyle: draw.TextStyle{Color: color.Black},
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (v *VRule) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	x := trX(v.X)
+
+	c.StrokeLine2(v.LineStyle, x, c.Min.Y, x, c.Max.Y)
+	c.FillText(v.TextStyle, vg.Point{X: x, Y: c.Max.Y}, v.Label)
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface. It reports no Y range of its own so it doesn't distort the
+// histogram it is overlaid on.
+func (v *VRule) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return v.X, v.X, math.Inf(1), math.Inf(-1)
+}